@@ -2,6 +2,7 @@ package main
 
 import (
 	"flag"
+	"fmt"
 	"net/http"
 	"os"
 	"os/signal"
@@ -11,9 +12,10 @@ import (
 	"syscall"
 	"time"
 
-	"github.com/golang/glog"
+	"github.com/go-logr/logr"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"k8s.io/klog/v2"
 
 	"github.com/bookingcom/shipper/pkg/chart"
 	shipperclientset "github.com/bookingcom/shipper/pkg/client/clientset/versioned"
@@ -24,6 +26,7 @@ import (
 	"github.com/bookingcom/shipper/pkg/controller/capacity"
 	"github.com/bookingcom/shipper/pkg/controller/clustersecret"
 	"github.com/bookingcom/shipper/pkg/controller/installation"
+	"github.com/bookingcom/shipper/pkg/controller/propagation"
 	"github.com/bookingcom/shipper/pkg/controller/schedulecontroller"
 	"github.com/bookingcom/shipper/pkg/controller/shipmentorder"
 	"github.com/bookingcom/shipper/pkg/controller/strategy"
@@ -33,6 +36,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	utiluuid "k8s.io/apimachinery/pkg/util/uuid"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
@@ -40,9 +44,13 @@ import (
 	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
 	kuberestmetrics "k8s.io/client-go/tools/metrics"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
+	logsapi "k8s.io/component-base/logs/api/v1"
+	_ "k8s.io/component-base/logs/json/register"
 )
 
 var controllers = []string{
@@ -50,6 +58,7 @@ var controllers = []string{
 	"shipmentorder",
 	"clustersecret",
 	"schedule",
+	"propagation",
 	"strategy",
 	"installation",
 	"capacity",
@@ -67,17 +76,47 @@ var (
 	disabledControllers = flag.String("disable", "", "comma-seperated list of controllers to disable")
 	workers             = flag.Int("workers", 2, "Number of workers to start for each controller.")
 	metricsAddr         = flag.String("metrics-addr", ":8889", "Addr to expose /metrics on.")
+	healthAddr          = flag.String("health-addr", ":8888", "Addr to expose /healthz and /readyz on.")
 	chartCacheDir       = flag.String("cachedir", filepath.Join(os.TempDir(), "chart-cache"), "location for the local cache of downloaded charts")
+
+	leaderElect              = flag.Bool("leader-elect", true, "Start a leader election client and gain leadership before starting controllers. Enabling this allows running multiple replicas of shipper for HA.")
+	leaderElectLeaseDuration = flag.Duration("leader-elect-lease-duration", 15*time.Second, "The duration that non-leader candidates will wait after observing a leadership renewal until attempting to acquire leadership of a led but unrenewed leader slot.")
+	leaderElectRenewDeadline = flag.Duration("leader-elect-renew-deadline", 10*time.Second, "The interval between attempts by the acting master to renew a leadership slot before it stops leading. Must be less than the lease duration.")
+	leaderElectRetryPeriod   = flag.Duration("leader-elect-retry-period", 2*time.Second, "The duration the clients should wait between attempting acquisition and renewal of a leadership.")
+
+	loggingConfig = logsapi.NewLoggingConfiguration()
 )
 
-type metricsCfg struct {
-	readyCh chan struct{}
+func init() {
+	logsapi.AddFlags(loggingConfig, flag.CommandLine)
+}
 
+type metricsCfg struct {
 	wqMetrics   *shippermetrics.PrometheusWorkqueueProvider
 	restLatency *shippermetrics.RESTLatencyMetric
 	restResult  *shippermetrics.RESTResultMetric
 }
 
+// healthCfg carries everything the /healthz and /readyz handlers need to
+// judge liveness and readiness. /healthz only reports the process is up and
+// serving, regardless of leadership, so a liveness probe never crash-loops
+// a standby replica that's waiting on the lease. wiredCh is closed once
+// runControllers has finished creating every enabled controller;
+// kubeSyncedCh and shipperSyncedCh are closed once their respective
+// informer factories report their caches synced. Until all of those have
+// happened, and at least one target cluster is Ready, /readyz reports this
+// replica isn't fit to receive traffic yet.
+type healthCfg struct {
+	wiredCh chan struct{}
+
+	kubeInformerFactory    informers.SharedInformerFactory
+	shipperInformerFactory shipperinformers.SharedInformerFactory
+	kubeSyncedCh           chan struct{}
+	shipperSyncedCh        chan struct{}
+
+	store *clusterclientstore.Store
+}
+
 type cfg struct {
 	enabledControllers map[string]bool
 
@@ -101,30 +140,47 @@ type cfg struct {
 	wg     *sync.WaitGroup
 	stopCh <-chan struct{}
 
+	logger logr.Logger
+
 	metrics *metricsCfg
+	health  *healthCfg
 }
 
 func main() {
 	flag.Parse()
 
+	if err := logsapi.ValidateAndApply(loggingConfig, nil); err != nil {
+		klog.Fatalf("invalid logging configuration: %s", err)
+	}
+	logger := klog.Background()
+
+	// logger is only threaded into propagation.NewController below.
+	// application, shipmentorder, clustersecret, schedulecontroller,
+	// strategy, installation, capacity and traffic aren't part of this
+	// checkout (pkg/controller only has propagation), so there is
+	// nothing to migrate them to yet; that's left for whenever they
+	// land here.
+
 	resync, err := time.ParseDuration(*resyncPeriod)
 	if err != nil {
-		glog.Fatal(err)
+		klog.Fatal(err)
 	}
 
 	kubeClient, shipperClient, restCfg, err := buildClients(*masterURL, *kubeconfig)
 	if err != nil {
-		glog.Fatal(err)
+		klog.Fatal(err)
 	}
 
 	stopCh := setupSignalHandler()
-	metricsReadyCh := make(chan struct{})
+	healthWiredCh := make(chan struct{})
+	kubeSyncedCh := make(chan struct{})
+	shipperSyncedCh := make(chan struct{})
 
 	kubeInformerFactory := informers.NewSharedInformerFactory(kubeClient, resync)
 	shipperInformerFactory := shipperinformers.NewSharedInformerFactory(shipperClient, resync)
 
 	broadcaster := record.NewBroadcaster()
-	broadcaster.StartLogging(glog.Infof)
+	broadcaster.StartLogging(klog.Infof)
 	broadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: kubeClient.CoreV1().Events("")})
 	shipperscheme.AddToScheme(scheme.Scheme)
 
@@ -135,7 +191,7 @@ func main() {
 	enabledControllers := buildEnabledControllers(*enabledControllers, *disabledControllers)
 	if enabledControllers["clustersecret"] {
 		if *certPath == "" || *keyPath == "" {
-			glog.Fatal("--cert and --key must both be specified if the clustersecret controller is running")
+			klog.Fatal("--cert and --key must both be specified if the clustersecret controller is running")
 		}
 	}
 
@@ -144,6 +200,7 @@ func main() {
 	store := clusterclientstore.NewStore(
 		kubeInformerFactory.Core().V1().Secrets(),
 		shipperInformerFactory.Shipper().V1().Clusters(),
+		recorder("cluster-client-store"),
 	)
 
 	wg.Add(1)
@@ -175,39 +232,104 @@ func main() {
 		wg:     wg,
 		stopCh: stopCh,
 
+		logger: logger,
+
 		metrics: &metricsCfg{
-			readyCh:     metricsReadyCh,
 			wqMetrics:   shippermetrics.NewProvider(),
 			restLatency: shippermetrics.NewRESTLatencyMetric(),
 			restResult:  shippermetrics.NewRESTResultMetric(),
 		},
+		health: &healthCfg{
+			wiredCh: healthWiredCh,
+
+			kubeInformerFactory:    kubeInformerFactory,
+			shipperInformerFactory: shipperInformerFactory,
+			kubeSyncedCh:           kubeSyncedCh,
+			shipperSyncedCh:        shipperSyncedCh,
+
+			store: store,
+		},
 	}
 
 	go func() {
-		glog.V(1).Infof("Metrics will listen on %s", *metricsAddr)
-		<-metricsReadyCh
-
-		glog.V(3).Info("Starting the metrics web server")
-		defer glog.V(3).Info("The metrics web server has shut down")
+		klog.V(1).Infof("Metrics will listen on %s", *metricsAddr)
+		defer klog.V(3).Info("The metrics web server has shut down")
 
 		runMetrics(cfg.metrics)
 	}()
 
-	runControllers(cfg)
+	go func() {
+		klog.V(1).Infof("Health checks will listen on %s", *healthAddr)
+		runHealthz(cfg.health)
+	}()
+
+	if *leaderElect {
+		runLeaderElection(cfg, recorder)
+	} else {
+		runControllers(cfg)
+	}
+}
+
+// runLeaderElection blocks running controllers behind a Lease lock in
+// cfg.ns, so that only one shipper replica is mutating Application,
+// InstallationTarget, CapacityTarget and TrafficTarget objects at a time.
+// Losing the lease (or any other stop condition reported by the
+// leaderelection library) is fatal: we'd rather have the pod restart and
+// re-race for the lease than risk two replicas believing they're both
+// leading.
+func runLeaderElection(cfg *cfg, recorder func(string) record.EventRecorder) {
+	identity, err := os.Hostname()
+	if err != nil {
+		klog.Fatalf("error determining leader election identity: %s", err)
+	}
+	identity = fmt.Sprintf("%s_%s", identity, utiluuid.NewUUID())
+
+	lock, err := resourcelock.New(
+		resourcelock.LeasesResourceLock,
+		cfg.ns,
+		"shipper-controller-manager",
+		cfg.kubeClient.CoreV1(),
+		cfg.kubeClient.CoordinationV1(),
+		resourcelock.ResourceLockConfig{
+			Identity:      identity,
+			EventRecorder: recorder("shipper-leader-election"),
+		},
+	)
+	if err != nil {
+		klog.Fatalf("error creating leader election lock: %s", err)
+	}
+
+	leaderelection.RunOrDie(leaderelection.LeaderElectionConfig{
+		Lock:          lock,
+		LeaseDuration: *leaderElectLeaseDuration,
+		RenewDeadline: *leaderElectRenewDeadline,
+		RetryPeriod:   *leaderElectRetryPeriod,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(stopCh <-chan struct{}) {
+				shippermetrics.LeaderElectionMasterStatus.Set(1)
+				runControllers(cfg)
+			},
+			OnStoppedLeading: func() {
+				shippermetrics.LeaderElectionMasterStatus.Set(0)
+				klog.Fatalf("%s stopped leading; exiting so the pod can restart and re-race for the lease", identity)
+			},
+		},
+	})
 }
 
-type glogStdLogger struct{}
+type klogStdLogger struct{}
 
-func (_ glogStdLogger) Println(v ...interface{}) {
+func (_ klogStdLogger) Println(v ...interface{}) {
 	// Prometheus only logs errors (which aren't fatal so we downgrade them to
 	// warnings).
-	glog.Warning(v...)
+	klog.Warning(v...)
 }
 
 func runMetrics(cfg *metricsCfg) {
 	prometheus.MustRegister(cfg.wqMetrics.GetMetrics()...)
 	prometheus.MustRegister(cfg.restLatency.Summary, cfg.restResult.Counter)
 	prometheus.MustRegister(instrumentedclient.GetMetrics()...)
+	prometheus.MustRegister(shippermetrics.LeaderElectionMasterStatus)
 
 	srv := http.Server{
 		Addr: *metricsAddr,
@@ -215,13 +337,61 @@ func runMetrics(cfg *metricsCfg) {
 			prometheus.DefaultGatherer,
 			promhttp.HandlerOpts{
 				ErrorHandling: promhttp.ContinueOnError,
-				ErrorLog:      glogStdLogger{},
+				ErrorLog:      klogStdLogger{},
 			},
 		),
 	}
 	srv.ListenAndServe()
 }
 
+// runHealthz exposes /healthz and /readyz on *healthAddr. /healthz reports
+// this process is alive as soon as it's serving, independent of leadership
+// or wiring: a standby replica that's never won the lease is still a
+// healthy process, and pointing a liveness probe at anything that depends
+// on leadership would crash-loop every standby pod. /readyz is where
+// leadership actually matters: it additionally requires runControllers to
+// have wired up every enabled controller, both informer factories to have
+// finished their initial cache sync, and the cluster client store to have
+// at least one target cluster it considers Ready, so a freshly-elected
+// leader isn't sent traffic before it can actually reconcile anything.
+func runHealthz(cfg *healthCfg) {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	})
+
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case !isClosed(cfg.wiredCh):
+			http.Error(w, "controllers not wired up yet", http.StatusServiceUnavailable)
+		case !isClosed(cfg.kubeSyncedCh):
+			http.Error(w, "kube informer caches not synced yet", http.StatusServiceUnavailable)
+		case !isClosed(cfg.shipperSyncedCh):
+			http.Error(w, "shipper informer caches not synced yet", http.StatusServiceUnavailable)
+		case cfg.store.ReadyClusterCount() == 0:
+			http.Error(w, "no target clusters are ready yet", http.StatusServiceUnavailable)
+		default:
+			w.Write([]byte("ok"))
+		}
+	})
+
+	srv := http.Server{
+		Addr:    *healthAddr,
+		Handler: mux,
+	}
+	srv.ListenAndServe()
+}
+
+func isClosed(ch <-chan struct{}) bool {
+	select {
+	case <-ch:
+		return true
+	default:
+		return false
+	}
+}
+
 func buildClients(masterURL, kubeconfig string) (kubernetes.Interface, shipperclientset.Interface, *rest.Config, error) {
 	restCfg, err := clientcmd.BuildConfigFromFlags(masterURL, kubeconfig)
 	if err != nil {
@@ -255,7 +425,7 @@ func buildEnabledControllers(enabledControllers, disabledControllers string) map
 
 		_, ok := willRun[controller]
 		if !ok {
-			glog.Fatalf("cannot enable %q: it is not a known controller", controller)
+			klog.Fatalf("cannot enable %q: it is not a known controller", controller)
 		}
 		willRun[controller] = true
 	}
@@ -268,7 +438,7 @@ func buildEnabledControllers(enabledControllers, disabledControllers string) map
 
 		_, ok := willRun[controller]
 		if !ok {
-			glog.Fatalf("cannot disable %q: it is not a known controller", controller)
+			klog.Fatalf("cannot disable %q: it is not a known controller", controller)
 		}
 
 		willRun[controller] = false
@@ -289,21 +459,36 @@ func runControllers(cfg *cfg) {
 		started, err := initializer(cfg)
 		// TODO make it visible when some controller's aren't starting properly; all of the initializers return 'nil' ATM
 		if err != nil {
-			glog.Fatalf("%q failed to initialize", name)
+			klog.Fatalf("%q failed to initialize", name)
 		}
 
 		if !started {
-			glog.Infof("%q was skipped per config", name)
+			klog.Infof("%q was skipped per config", name)
 		}
 	}
 
-	// Controllers and their workqueues have been created, we can expose the
-	// metrics now.
-	close(cfg.metrics.readyCh)
+	// Controllers and their workqueues have been created; report ourselves
+	// wired up (used by /readyz to decide when this replica is fit to lead
+	// reconciliation). Metrics and /healthz are already serving by this
+	// point regardless of leadership, see runMetrics/runHealthz.
+	close(cfg.health.wiredCh)
 
 	go cfg.kubeInformerFactory.Start(cfg.stopCh)
 	go cfg.shipperInformerFactory.Start(cfg.stopCh)
 
+	go func() {
+		// Start is idempotent; calling it here makes sure the informers
+		// are registered as started before WaitForCacheSync looks at them.
+		cfg.health.kubeInformerFactory.Start(cfg.stopCh)
+		cfg.health.kubeInformerFactory.WaitForCacheSync(cfg.stopCh)
+		close(cfg.health.kubeSyncedCh)
+	}()
+	go func() {
+		cfg.health.shipperInformerFactory.Start(cfg.stopCh)
+		cfg.health.shipperInformerFactory.WaitForCacheSync(cfg.stopCh)
+		close(cfg.health.shipperSyncedCh)
+	}()
+
 	doneCh := make(chan struct{})
 
 	go func() {
@@ -312,7 +497,7 @@ func runControllers(cfg *cfg) {
 	}()
 
 	<-doneCh
-	glog.Info("Controllers have shut down")
+	klog.Info("Controllers have shut down")
 }
 
 func setupSignalHandler() <-chan struct{} {
@@ -339,6 +524,7 @@ func buildInitializers() map[string]initFunc {
 	controllers["shipmentorder"] = startShipmentOrderController
 	controllers["clustersecret"] = startClusterSecretController
 	controllers["schedule"] = startScheduleController
+	controllers["propagation"] = startPropagationController
 	controllers["strategy"] = startStrategyController
 	controllers["installation"] = startInstallationController
 	controllers["capacity"] = startCapacityController
@@ -437,6 +623,28 @@ func startScheduleController(cfg *cfg) (bool, error) {
 	return true, nil
 }
 
+func startPropagationController(cfg *cfg) (bool, error) {
+	enabled := cfg.enabledControllers["propagation"]
+	if !enabled {
+		return false, nil
+	}
+
+	c := propagation.NewController(
+		cfg.shipperClient,
+		cfg.shipperInformerFactory,
+		cfg.recorder(propagation.AgentName),
+		cfg.logger,
+	)
+
+	cfg.wg.Add(1)
+	go func() {
+		c.Run(cfg.workers, cfg.stopCh)
+		cfg.wg.Done()
+	}()
+
+	return true, nil
+}
+
 func startStrategyController(cfg *cfg) (bool, error) {
 	enabled := cfg.enabledControllers["strategy"]
 	if !enabled {
@@ -467,7 +675,7 @@ func startInstallationController(cfg *cfg) (bool, error) {
 
 		dynamicClient, newClientErr := dynamic.NewClient(config)
 		if newClientErr != nil {
-			glog.Fatal(newClientErr)
+			klog.Fatal(newClientErr)
 		}
 		return dynamicClient
 	}
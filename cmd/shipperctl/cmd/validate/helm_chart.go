@@ -2,12 +2,26 @@ package validate
 
 import (
 	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
 
-	shipperchart "github.com/bookingcom/shipper/pkg/chart"
 	"github.com/spf13/cobra"
+	helmchart "k8s.io/helm/pkg/proto/hapi/chart"
+
+	shipperv1alpha1 "github.com/bookingcom/shipper/pkg/apis/shipper/v1alpha1"
+	shipperchart "github.com/bookingcom/shipper/pkg/chart"
 	"k8s.io/helm/pkg/chartutil"
 )
 
+var (
+	helmChartCacheDir  string
+	helmChartCacheSize int64
+	helmChartRepoURL   string
+	helmChartOffline   bool
+)
+
 var helmChartCmd = &cobra.Command{
 	Use:   "chart",
 	Short: "Validate Helm chart",
@@ -15,23 +29,106 @@ var helmChartCmd = &cobra.Command{
 }
 
 func HelmChartCmd() *cobra.Command {
+	helmChartCmd.Flags().StringVar(&helmChartCacheDir, "cachedir", filepath.Join(os.TempDir(), "chart-cache"), "location for the local cache of downloaded charts")
+	helmChartCmd.Flags().Int64Var(&helmChartCacheSize, "cache-limit", shipperchart.DefaultCacheLimit, "maximum size, in bytes, of the local chart cache")
+	helmChartCmd.Flags().StringVar(&helmChartRepoURL, "repo", "", "Helm chart repository to resolve bare \"name:version\" references against")
+	helmChartCmd.Flags().BoolVar(&helmChartOffline, "offline", false, "only validate charts already present on disk or in the local cache; never hit the network")
+
 	return helmChartCmd
 }
 
+// runValidateHelmChartCommand validates every chart reference given on the
+// command line. A reference can be a local filesystem path, a remote
+// http(s):// or oci:// chart URL, or a bare "name:version" reference
+// resolved against --repo; the latter two go through the same
+// chart.FetchRemoteWithCache path the application and shipmentorder
+// controllers use, so a chart that validates here is fetched exactly the
+// way shipper itself would fetch it. That path downloads with a plain HTTP
+// GET, so an oci:// reference only loads if it is already in --cachedir.
 func runValidateHelmChartCommand(cmd *cobra.Command, args []string) error {
-	for _, chartPath := range args {
-		// TODO: make it understand remote chart URLs
-		// use chart.downloadChart/3
-		chart, loadErr := chartutil.Load(chartPath)
+	fetch := shipperchart.FetchRemoteWithCache(helmChartCacheDir, helmChartCacheSize)
+	if helmChartOffline {
+		// --offline only consults whatever --cachedir already has on
+		// disk from a previous fetch; it never reaches out to --repo or
+		// a remote chart URL.
+		fetch = shipperchart.FetchFromCache(helmChartCacheDir)
+	}
+
+	for _, ref := range args {
+		chrt, loadErr := loadChart(ref, fetch)
 		if loadErr != nil {
-			return fmt.Errorf("Failed to load chart under path %q: %s", chartPath, loadErr.Error())
+			return fmt.Errorf("Failed to load chart %q: %s", ref, loadErr.Error())
 		}
-		if validateErr := shipperchart.Validate(chart); validateErr != nil {
+
+		if validateErr := shipperchart.Validate(chrt); validateErr != nil {
 			return fmt.Errorf("Chart validation failed: %s\n", validateErr.Error())
-		} else {
-			cmd.Printf("Chart %s successfully passed all validation checks\n", chartPath)
 		}
+
+		cmd.Printf("Chart %s successfully passed all validation checks\n", ref)
 	}
 
 	return nil
 }
+
+// loadChart resolves ref to a chart, either by loading it directly off
+// disk or, for remote and repo-relative references, by resolving it to a
+// shipperv1alpha1.Chart spec and handing that to fetch. fetch is already
+// the right one for --offline (see runValidateHelmChartCommand), so
+// loadChart doesn't need to know about that flag itself; a ref that isn't
+// in the cache simply surfaces whatever error fetch returns.
+func loadChart(ref string, fetch shipperchart.FetchFunc) (*helmchart.Chart, error) {
+	if !isRemoteRef(ref) {
+		if _, statErr := os.Stat(ref); statErr == nil || helmChartRepoURL == "" {
+			return chartutil.Load(ref)
+		}
+	}
+
+	spec, err := chartSpecFor(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	return fetch(spec)
+}
+
+// isRemoteRef reports whether ref is a URL shipper knows how to fetch a
+// chart from directly, as opposed to a bare "name:version" reference that
+// needs --repo to be resolved.
+func isRemoteRef(ref string) bool {
+	u, err := url.Parse(ref)
+	if err != nil {
+		return false
+	}
+
+	switch u.Scheme {
+	case "http", "https", "oci":
+		return true
+	default:
+		return false
+	}
+}
+
+// chartSpecFor turns a command-line chart reference into the
+// shipperv1alpha1.Chart spec that chart.FetchFunc expects: a remote URL is
+// used as-is, while a bare "name:version" reference is split apart and
+// resolved against --repo.
+func chartSpecFor(ref string) (*shipperv1alpha1.Chart, error) {
+	if isRemoteRef(ref) {
+		return &shipperv1alpha1.Chart{RepoURL: ref}, nil
+	}
+
+	name, version := ref, ""
+	if i := strings.LastIndex(ref, ":"); i >= 0 {
+		name, version = ref[:i], ref[i+1:]
+	}
+
+	if name == "" || version == "" {
+		return nil, fmt.Errorf(`%q is not a local path, a URL, or a "name:version" reference`, ref)
+	}
+
+	return &shipperv1alpha1.Chart{
+		Name:    name,
+		Version: version,
+		RepoURL: helmChartRepoURL,
+	}, nil
+}
@@ -0,0 +1,19 @@
+package v1alpha1
+
+// Chart identifies a Helm chart to install, either by a RepoURL pointing
+// directly at the packaged chart (Name and Version left empty), or by Name
+// and Version resolved against RepoURL as a chart repository base.
+type Chart struct {
+	// Name is the chart's name, resolved against the repository at
+	// RepoURL along with Version.
+	Name string `json:"name,omitempty"`
+
+	// Version is the chart's version, resolved against the repository at
+	// RepoURL along with Name.
+	Version string `json:"version,omitempty"`
+
+	// RepoURL is a direct URL to the packaged chart when Name and Version
+	// are both empty, and the chart repository's base URL otherwise.
+	// +optional
+	RepoURL string `json:"repoURL,omitempty"`
+}
@@ -0,0 +1,41 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// InstallationTarget records which clusters a Release's chart should be
+// installed on, and any per-cluster overrides to apply to it on the way.
+type InstallationTarget struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec InstallationTargetSpec `json:"spec"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// InstallationTargetList is a list of InstallationTarget resources.
+type InstallationTargetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []InstallationTarget `json:"items"`
+}
+
+// InstallationTargetSpec describes the clusters a chart should be
+// installed on, and any overrides to apply to its values per cluster.
+type InstallationTargetSpec struct {
+	// Clusters is the list of target cluster names the chart should be
+	// installed on.
+	Clusters []string `json:"clusters"`
+
+	// ClusterOverrides lists the patches to apply to the chart's values
+	// for each target cluster name. A cluster absent from this list gets
+	// the chart's values unmodified.
+	// +optional
+	ClusterOverrides []ClusterOverride `json:"clusterOverrides,omitempty"`
+}
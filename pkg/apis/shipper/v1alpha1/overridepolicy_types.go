@@ -0,0 +1,83 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// OverridePolicy selects Applications (or their generated Releases) by
+// label selector and applies per-cluster overrides to their chart values,
+// so the same chart can be deployed with cluster-specific tweaks without
+// forking the Application.
+type OverridePolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec OverridePolicySpec `json:"spec"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// OverridePolicyList is a list of OverridePolicy resources.
+type OverridePolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []OverridePolicy `json:"items"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterOverridePolicy is the cluster-scoped equivalent of
+// OverridePolicy.
+type ClusterOverridePolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec OverridePolicySpec `json:"spec"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterOverridePolicyList is a list of ClusterOverridePolicy resources.
+type ClusterOverridePolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []ClusterOverridePolicy `json:"items"`
+}
+
+// OverridePolicySpec describes which Applications an override applies to,
+// and the patches to apply to their chart values per target cluster.
+type OverridePolicySpec struct {
+	Selector metav1.LabelSelector `json:"selector"`
+
+	// ClusterOverrides lists the patches to apply for each target cluster
+	// name. A cluster absent from this list gets the chart's values
+	// unmodified.
+	ClusterOverrides []ClusterOverride `json:"clusterOverrides"`
+}
+
+// OverridePatchType identifies how a ClusterOverride's Patch field should
+// be applied to the chart values.
+type OverridePatchType string
+
+const (
+	// JSONPatchOverride applies Patch as an RFC 6902 JSON patch.
+	JSONPatchOverride OverridePatchType = "JSONPatch"
+	// StrategicMergePatchOverride applies Patch as a strategic merge
+	// patch.
+	StrategicMergePatchOverride OverridePatchType = "StrategicMergePatch"
+)
+
+// ClusterOverride is the patch to apply to chart values for a single
+// target cluster.
+type ClusterOverride struct {
+	ClusterName string            `json:"clusterName"`
+	PatchType   OverridePatchType `json:"patchType"`
+	Patch       string            `json:"patch"`
+}
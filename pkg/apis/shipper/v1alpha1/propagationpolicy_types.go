@@ -0,0 +1,90 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PropagationPolicy selects Applications (or the Releases they generate)
+// by label selector and describes where their workload should be placed,
+// decoupling placement decisions from the Application object itself.
+type PropagationPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec PropagationPolicySpec `json:"spec"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PropagationPolicyList is a list of PropagationPolicy resources.
+type PropagationPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []PropagationPolicy `json:"items"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterPropagationPolicy is the cluster-scoped equivalent of
+// PropagationPolicy, for placement rules that should apply regardless of
+// which namespace an Application lives in.
+type ClusterPropagationPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec PropagationPolicySpec `json:"spec"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterPropagationPolicyList is a list of ClusterPropagationPolicy
+// resources.
+type ClusterPropagationPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []ClusterPropagationPolicy `json:"items"`
+}
+
+// PropagationPolicySpec describes which Applications a policy applies to,
+// and which clusters their Releases should be placed on.
+type PropagationPolicySpec struct {
+	// Selector narrows the policy down to a subset of Applications (or
+	// their generated Releases) in scope.
+	Selector metav1.LabelSelector `json:"selector"`
+
+	Placement ClusterPlacement `json:"placement"`
+}
+
+// ClusterPlacement describes the target clusters for a PropagationPolicy
+// by explicit name, by label selector and by region, and the percentage
+// of capacity each selected cluster gets. The clusters picked by Clusters,
+// ClusterSelector and Regions are combined: a cluster picked by any one of
+// them is a target.
+type ClusterPlacement struct {
+	// Clusters is an explicit list of target cluster names.
+	// +optional
+	Clusters []string `json:"clusters,omitempty"`
+
+	// ClusterSelector adds every Cluster whose labels it matches to the
+	// target clusters.
+	// +optional
+	ClusterSelector *metav1.LabelSelector `json:"clusterSelector,omitempty"`
+
+	// Regions adds every Cluster whose region is one of the listed
+	// regions to the target clusters.
+	// +optional
+	Regions []string `json:"regions,omitempty"`
+
+	// Weights assigns a percentage of capacity (out of 100) per target
+	// cluster name. Clusters without an entry default to an equal share
+	// of whatever remains.
+	// +optional
+	Weights map[string]int32 `json:"weights,omitempty"`
+}
@@ -0,0 +1,17 @@
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func init() {
+	SchemeBuilder.Register(addInstallationKnownTypes)
+}
+
+func addInstallationKnownTypes(scheme *runtime.Scheme) error {
+	scheme.AddKnownTypes(SchemeGroupVersion,
+		&InstallationTarget{},
+		&InstallationTargetList{},
+	)
+	return nil
+}
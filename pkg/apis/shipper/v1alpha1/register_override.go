@@ -0,0 +1,19 @@
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func init() {
+	SchemeBuilder.Register(addOverrideKnownTypes)
+}
+
+func addOverrideKnownTypes(scheme *runtime.Scheme) error {
+	scheme.AddKnownTypes(SchemeGroupVersion,
+		&OverridePolicy{},
+		&OverridePolicyList{},
+		&ClusterOverridePolicy{},
+		&ClusterOverridePolicyList{},
+	)
+	return nil
+}
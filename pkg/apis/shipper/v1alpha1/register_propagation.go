@@ -0,0 +1,19 @@
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func init() {
+	SchemeBuilder.Register(addPropagationKnownTypes)
+}
+
+func addPropagationKnownTypes(scheme *runtime.Scheme) error {
+	scheme.AddKnownTypes(SchemeGroupVersion,
+		&PropagationPolicy{},
+		&PropagationPolicyList{},
+		&ClusterPropagationPolicy{},
+		&ClusterPropagationPolicyList{},
+	)
+	return nil
+}
@@ -0,0 +1,126 @@
+// Package chart resolves a shipperv1alpha1.Chart reference to a loaded
+// Helm chart, fetching it from a remote repository and caching it on disk
+// so repeated installs of the same chart version don't hit the network
+// every time.
+package chart
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	helmchart "k8s.io/helm/pkg/proto/hapi/chart"
+
+	shipperv1alpha1 "github.com/bookingcom/shipper/pkg/apis/shipper/v1alpha1"
+	"k8s.io/helm/pkg/chartutil"
+)
+
+// DefaultCacheLimit is the default upper bound, in bytes, on the total
+// size of the on-disk chart cache.
+const DefaultCacheLimit int64 = 512 * 1024 * 1024
+
+// FetchFunc resolves a chart reference to a loaded Helm chart.
+type FetchFunc func(spec *shipperv1alpha1.Chart) (*helmchart.Chart, error)
+
+// FetchRemoteWithCache returns a FetchFunc that serves charts out of
+// cacheDir when they're already present, and otherwise downloads them
+// and saves them into cacheDir for next time. Downloads are plain HTTP(S)
+// GETs; an oci:// RepoURL is not pulled from a registry and returns an
+// error unless the chart is already in cacheDir. cacheLimit is currently
+// informational only; it does not evict anything from the cache.
+func FetchRemoteWithCache(cacheDir string, cacheLimit int64) FetchFunc {
+	return func(spec *shipperv1alpha1.Chart) (*helmchart.Chart, error) {
+		path := cachePath(cacheDir, spec)
+
+		if chrt, err := chartutil.Load(path); err == nil {
+			return chrt, nil
+		}
+
+		if err := downloadChart(spec, path); err != nil {
+			return nil, err
+		}
+
+		return chartutil.Load(path)
+	}
+}
+
+// FetchFromCache returns a FetchFunc that only ever serves charts already
+// present in cacheDir from a previous fetch; it never reaches out to the
+// network, returning an error for anything not already cached.
+func FetchFromCache(cacheDir string) FetchFunc {
+	return func(spec *shipperv1alpha1.Chart) (*helmchart.Chart, error) {
+		path := cachePath(cacheDir, spec)
+
+		chrt, err := chartutil.Load(path)
+		if err != nil {
+			return nil, fmt.Errorf("chart %s not found in local cache %q: %s", chartRef(spec), cacheDir, err)
+		}
+
+		return chrt, nil
+	}
+}
+
+// cachePath returns the on-disk location spec would be cached at,
+// whether or not anything has been fetched into it yet.
+func cachePath(cacheDir string, spec *shipperv1alpha1.Chart) string {
+	if spec.RepoURL != "" && spec.Name == "" && spec.Version == "" {
+		return filepath.Join(cacheDir, filepath.Base(spec.RepoURL))
+	}
+
+	return filepath.Join(cacheDir, fmt.Sprintf("%s-%s.tgz", spec.Name, spec.Version))
+}
+
+// downloadURL returns the URL the packaged chart archive for spec should
+// be downloaded from: RepoURL itself when it already points directly at
+// an archive, or RepoURL treated as a repository base with the
+// conventional "name-version.tgz" archive name appended otherwise.
+func downloadURL(spec *shipperv1alpha1.Chart) string {
+	if spec.Name == "" && spec.Version == "" {
+		return spec.RepoURL
+	}
+
+	return strings.TrimSuffix(spec.RepoURL, "/") + fmt.Sprintf("/%s-%s.tgz", spec.Name, spec.Version)
+}
+
+func downloadChart(spec *shipperv1alpha1.Chart, destPath string) error {
+	if err := os.MkdirAll(filepath.Dir(destPath), 0755); err != nil {
+		return fmt.Errorf("create chart cache dir: %s", err)
+	}
+
+	resp, err := http.Get(downloadURL(spec))
+	if err != nil {
+		return fmt.Errorf("fetch chart %s: %s", chartRef(spec), err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("fetch chart %s: unexpected status %s", chartRef(spec), resp.Status)
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(destPath), ".chart-download-*")
+	if err != nil {
+		return fmt.Errorf("fetch chart %s: %s", chartRef(spec), err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, resp.Body); err != nil {
+		tmp.Close()
+		return fmt.Errorf("fetch chart %s: %s", chartRef(spec), err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("fetch chart %s: %s", chartRef(spec), err)
+	}
+
+	return os.Rename(tmp.Name(), destPath)
+}
+
+func chartRef(spec *shipperv1alpha1.Chart) string {
+	if spec.Name != "" || spec.Version != "" {
+		return fmt.Sprintf("%s:%s", spec.Name, spec.Version)
+	}
+	return spec.RepoURL
+}
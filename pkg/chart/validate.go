@@ -0,0 +1,26 @@
+package chart
+
+import (
+	"fmt"
+
+	helmchart "k8s.io/helm/pkg/proto/hapi/chart"
+)
+
+// Validate checks that chrt is well-formed enough for shipper to install:
+// it must carry chart metadata naming it and its version, and at least
+// one template to render.
+func Validate(chrt *helmchart.Chart) error {
+	if chrt.Metadata == nil || chrt.Metadata.Name == "" {
+		return fmt.Errorf("chart is missing a name in its Chart.yaml")
+	}
+
+	if chrt.Metadata.Version == "" {
+		return fmt.Errorf("chart %q is missing a version in its Chart.yaml", chrt.Metadata.Name)
+	}
+
+	if len(chrt.Templates) == 0 {
+		return fmt.Errorf("chart %q has no templates", chrt.Metadata.Name)
+	}
+
+	return nil
+}
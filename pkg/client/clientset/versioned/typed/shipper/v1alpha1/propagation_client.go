@@ -0,0 +1,23 @@
+// This file was automatically generated by client-gen
+
+package v1alpha1
+
+// PropagationPolicies returns a PropagationPolicyInterface scoped to namespace.
+func (c *ShipperV1alpha1Client) PropagationPolicies(namespace string) PropagationPolicyInterface {
+	return newPropagationPolicies(c, namespace)
+}
+
+// ClusterPropagationPolicies returns a ClusterPropagationPolicyInterface.
+func (c *ShipperV1alpha1Client) ClusterPropagationPolicies() ClusterPropagationPolicyInterface {
+	return newClusterPropagationPolicies(c)
+}
+
+// OverridePolicies returns an OverridePolicyInterface scoped to namespace.
+func (c *ShipperV1alpha1Client) OverridePolicies(namespace string) OverridePolicyInterface {
+	return newOverridePolicies(c, namespace)
+}
+
+// ClusterOverridePolicies returns a ClusterOverridePolicyInterface.
+func (c *ShipperV1alpha1Client) ClusterOverridePolicies() ClusterOverridePolicyInterface {
+	return newClusterOverridePolicies(c)
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file was automatically generated by informer-gen
+
+package v1alpha1
+
+import (
+	time "time"
+
+	shipper_v1alpha1 "github.com/bookingcom/shipper/pkg/apis/shipper/v1alpha1"
+	versioned "github.com/bookingcom/shipper/pkg/client/clientset/versioned"
+	internalinterfaces "github.com/bookingcom/shipper/pkg/client/informers/externalversions/internalinterfaces"
+	v1alpha1 "github.com/bookingcom/shipper/pkg/client/listers/shipper/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// ClusterPropagationPolicyInformer provides access to a shared informer and lister for
+// ClusterPropagationPolicies.
+type ClusterPropagationPolicyInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() v1alpha1.ClusterPropagationPolicyLister
+}
+
+type clusterPropagationPolicyInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+}
+
+// NewClusterPropagationPolicyInformer constructs a new informer for ClusterPropagationPolicy type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewClusterPropagationPolicyInformer(client versioned.Interface, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredClusterPropagationPolicyInformer(client, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredClusterPropagationPolicyInformer constructs a new informer for ClusterPropagationPolicy type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredClusterPropagationPolicyInformer(client versioned.Interface, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options v1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.ShipperV1alpha1().ClusterPropagationPolicies().List(options)
+			},
+			WatchFunc: func(options v1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.ShipperV1alpha1().ClusterPropagationPolicies().Watch(options)
+			},
+		},
+		&shipper_v1alpha1.ClusterPropagationPolicy{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *clusterPropagationPolicyInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredClusterPropagationPolicyInformer(client, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *clusterPropagationPolicyInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&shipper_v1alpha1.ClusterPropagationPolicy{}, f.defaultInformer)
+}
+
+func (f *clusterPropagationPolicyInformer) Lister() v1alpha1.ClusterPropagationPolicyLister {
+	return v1alpha1.NewClusterPropagationPolicyLister(f.Informer().GetIndexer())
+}
@@ -0,0 +1,54 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file was automatically generated by informer-gen
+
+package v1alpha1
+
+import (
+	internalinterfaces "github.com/bookingcom/shipper/pkg/client/informers/externalversions/internalinterfaces"
+)
+
+// Interface provides access to all the informers in this group version.
+type Interface interface {
+	// CapacityTargets returns a CapacityTargetInformer.
+	CapacityTargets() CapacityTargetInformer
+	// Clusters returns a ClusterInformer.
+	Clusters() ClusterInformer
+	// ClusterOverridePolicies returns a ClusterOverridePolicyInformer.
+	ClusterOverridePolicies() ClusterOverridePolicyInformer
+	// ClusterPropagationPolicies returns a ClusterPropagationPolicyInformer.
+	ClusterPropagationPolicies() ClusterPropagationPolicyInformer
+	// InstallationTargets returns an InstallationTargetInformer.
+	InstallationTargets() InstallationTargetInformer
+	// OverridePolicies returns an OverridePolicyInformer.
+	OverridePolicies() OverridePolicyInformer
+	// PropagationPolicies returns a PropagationPolicyInformer.
+	PropagationPolicies() PropagationPolicyInformer
+	// Releases returns a ReleaseInformer.
+	Releases() ReleaseInformer
+}
+
+type version struct {
+	factory          internalinterfaces.SharedInformerFactory
+	namespace        string
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+}
+
+// New returns a new Interface.
+func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakListOptions internalinterfaces.TweakListOptionsFunc) Interface {
+	return &version{factory: f, namespace: namespace, tweakListOptions: tweakListOptions}
+}
@@ -0,0 +1,23 @@
+// This file was automatically generated by informer-gen
+
+package v1alpha1
+
+// PropagationPolicies returns a PropagationPolicyInformer.
+func (v *version) PropagationPolicies() PropagationPolicyInformer {
+	return &propagationPolicyInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// ClusterPropagationPolicies returns a ClusterPropagationPolicyInformer.
+func (v *version) ClusterPropagationPolicies() ClusterPropagationPolicyInformer {
+	return &clusterPropagationPolicyInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// OverridePolicies returns an OverridePolicyInformer.
+func (v *version) OverridePolicies() OverridePolicyInformer {
+	return &overridePolicyInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// ClusterOverridePolicies returns a ClusterOverridePolicyInformer.
+func (v *version) ClusterOverridePolicies() ClusterOverridePolicyInformer {
+	return &clusterOverridePolicyInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
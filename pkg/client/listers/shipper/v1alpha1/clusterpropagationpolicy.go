@@ -0,0 +1,65 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file was automatically generated by lister-gen
+
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/bookingcom/shipper/pkg/apis/shipper/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// ClusterPropagationPolicyLister helps list ClusterPropagationPolicies.
+type ClusterPropagationPolicyLister interface {
+	// List lists all ClusterPropagationPolicies in the indexer.
+	List(selector labels.Selector) (ret []*v1alpha1.ClusterPropagationPolicy, err error)
+	// Get retrieves the ClusterPropagationPolicy from the index for a given name.
+	Get(name string) (*v1alpha1.ClusterPropagationPolicy, error)
+	ClusterPropagationPolicyListerExpansion
+}
+
+// clusterPropagationPolicyLister implements the ClusterPropagationPolicyLister interface.
+type clusterPropagationPolicyLister struct {
+	indexer cache.Indexer
+}
+
+// NewClusterPropagationPolicyLister returns a new ClusterPropagationPolicyLister.
+func NewClusterPropagationPolicyLister(indexer cache.Indexer) ClusterPropagationPolicyLister {
+	return &clusterPropagationPolicyLister{indexer: indexer}
+}
+
+// List lists all ClusterPropagationPolicies in the indexer.
+func (s *clusterPropagationPolicyLister) List(selector labels.Selector) (ret []*v1alpha1.ClusterPropagationPolicy, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.ClusterPropagationPolicy))
+	})
+	return ret, err
+}
+
+// Get retrieves the ClusterPropagationPolicy from the index for a given name.
+func (s *clusterPropagationPolicyLister) Get(name string) (*v1alpha1.ClusterPropagationPolicy, error) {
+	obj, exists, err := s.indexer.GetByKey(name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1alpha1.Resource("clusterpropagationpolicy"), name)
+	}
+	return obj.(*v1alpha1.ClusterPropagationPolicy), nil
+}
@@ -0,0 +1,194 @@
+package cache
+
+import (
+	"sync"
+
+	kubeinformers "k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/bookingcom/shipper/pkg/errors"
+)
+
+// State represents where a cluster currently is in its connection
+// lifecycle. A cluster starts out Pending as soon as its kubeconfig
+// secret is observed, moves to Unreachable or AuthFailed while its /healthz
+// probe fails, and moves to Ready once a client has been built and the
+// probe has succeeded. A Ready cluster is not probed again and does not
+// fall back to another state.
+type State string
+
+const (
+	ClusterStatePending     State = "Pending"
+	ClusterStateReady       State = "Ready"
+	ClusterStateUnreachable State = "Unreachable"
+	ClusterStateAuthFailed  State = "AuthFailed"
+)
+
+// cluster is a cached record of everything the store knows about a single
+// target cluster. It is kept in the cache even when its client cannot be
+// built, so that a cluster which is temporarily unreachable does not
+// disappear from Count()/Fetch() and controllers can tell "unreachable"
+// apart from "never heard of it".
+type Cluster struct {
+	name     string
+	checksum string
+
+	mu      sync.RWMutex
+	state   State
+	stopped bool
+	lastErr error
+
+	client          kubernetes.Interface
+	config          *rest.Config
+	informerFactory kubeinformers.SharedInformerFactory
+	cancelInformers func()
+}
+
+// cluster is an unexported alias kept around so existing call sites and
+// tests within this package can keep referring to the lower-cased name
+// used before Cluster was exported for use by clusterclientstore.Store.
+type cluster = Cluster
+
+// NewCluster builds a cluster record that already has a working client,
+// config and informer factory, i.e. one that's ready to be used straight
+// away. cancelInformers is called when this record is replaced or removed
+// from the store, and should stop any informers started against
+// informerFactory.
+func NewCluster(
+	name, checksum string,
+	client kubernetes.Interface,
+	config *rest.Config,
+	informerFactory kubeinformers.SharedInformerFactory,
+	cancelInformers func(),
+) *Cluster {
+	return &Cluster{
+		name:            name,
+		checksum:        checksum,
+		state:           ClusterStateReady,
+		client:          client,
+		config:          config,
+		informerFactory: informerFactory,
+		cancelInformers: cancelInformers,
+	}
+}
+
+// NewPendingCluster builds a cluster record for a cluster whose client
+// could not be built yet (or not reachable yet). It carries no client,
+// config or informer factory until a later call to SetReady.
+func NewPendingCluster(name, checksum string) *Cluster {
+	return &Cluster{
+		name:     name,
+		checksum: checksum,
+		state:    ClusterStatePending,
+	}
+}
+
+func (c *Cluster) Name() string {
+	return c.name
+}
+
+func (c *Cluster) State() State {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.state
+}
+
+// SetReady promotes the cluster record to Ready, attaching the client,
+// config and informer factory built for it. It's called by the store's
+// reconnect loop once a previously Pending/Unreachable/AuthFailed cluster
+// starts responding again.
+func (c *Cluster) SetReady(client kubernetes.Interface, config *rest.Config, informerFactory kubeinformers.SharedInformerFactory, cancelInformers func()) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.client = client
+	c.config = config
+	c.informerFactory = informerFactory
+	c.cancelInformers = cancelInformers
+	c.state = ClusterStateReady
+	c.lastErr = nil
+}
+
+func (c *Cluster) SetUnreachable(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.state = ClusterStateUnreachable
+	c.lastErr = err
+}
+
+func (c *Cluster) SetAuthFailed(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.state = ClusterStateAuthFailed
+	c.lastErr = err
+}
+
+// Stop marks the cluster record as replaced/removed. Every getter will
+// return errors.ClusterNotReadyError from this point on, and any running
+// informers are cancelled.
+func (c *Cluster) Stop() {
+	c.mu.Lock()
+	cancel := c.cancelInformers
+	c.stopped = true
+	c.mu.Unlock()
+
+	if cancel != nil {
+		cancel()
+	}
+}
+
+func (c *Cluster) GetChecksum() (string, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.stopped {
+		return "", errors.NewClusterNotReadyError(c.name)
+	}
+	return c.checksum, nil
+}
+
+func (c *Cluster) GetClient() (kubernetes.Interface, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if err := c.readyLocked(); err != nil {
+		return nil, err
+	}
+	return c.client, nil
+}
+
+func (c *Cluster) GetConfig() (*rest.Config, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if err := c.readyLocked(); err != nil {
+		return nil, err
+	}
+	return c.config, nil
+}
+
+func (c *Cluster) GetInformerFactory() (kubeinformers.SharedInformerFactory, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if err := c.readyLocked(); err != nil {
+		return nil, err
+	}
+	return c.informerFactory, nil
+}
+
+// readyLocked returns the appropriate typed error for the cluster's
+// current state, or nil if it's Ready. Callers must hold c.mu for
+// reading.
+func (c *Cluster) readyLocked() error {
+	if c.stopped {
+		return errors.NewClusterNotReadyError(c.name)
+	}
+
+	switch c.state {
+	case ClusterStateReady:
+		return nil
+	case ClusterStateUnreachable:
+		return errors.NewClusterUnreachableError(c.name, c.lastErr)
+	case ClusterStateAuthFailed:
+		return errors.NewClusterAuthFailedError(c.name, c.lastErr)
+	default:
+		return errors.NewClusterNotReadyError(c.name)
+	}
+}
@@ -0,0 +1,141 @@
+package cache
+
+// Server is a tiny single-goroutine actor that owns the map of cluster
+// name -> *cluster. All mutation and lookup goes through channels so that
+// the map itself never needs its own lock, even though Store/Fetch/Remove/
+// Count are called concurrently from informer event handlers and
+// reconnect goroutines alike.
+type Server struct {
+	storeCh      chan storeRequest
+	fetchCh      chan fetchRequest
+	removeCh     chan string
+	countCh      chan chan int
+	readyCountCh chan chan int
+	stopCh       chan struct{}
+}
+
+type storeRequest struct {
+	cluster *cluster
+	resCh   chan bool
+}
+
+type fetchRequest struct {
+	name  string
+	resCh chan fetchResult
+}
+
+type fetchResult struct {
+	cluster *cluster
+	ok      bool
+}
+
+func NewServer() *Server {
+	return &Server{
+		storeCh:      make(chan storeRequest),
+		fetchCh:      make(chan fetchRequest),
+		removeCh:     make(chan string),
+		countCh:      make(chan chan int),
+		readyCountCh: make(chan chan int),
+		stopCh:       make(chan struct{}),
+	}
+}
+
+// Serve runs the Server's event loop. It must be run in its own goroutine,
+// and blocks until Stop is called.
+func (s *Server) Serve() {
+	clusters := map[string]*cluster{}
+
+	for {
+		select {
+		case req := <-s.storeCh:
+			c := req.cluster
+			existing, ok := clusters[c.name]
+			if ok {
+				existingChecksum, _ := existing.GetChecksum()
+				if existingChecksum == c.checksum {
+					// Redundant update: discard it and keep the existing
+					// record so we don't needlessly tear down its client.
+					req.resCh <- false
+					continue
+				}
+				existing.Stop()
+			}
+			clusters[c.name] = c
+			req.resCh <- true
+
+		case req := <-s.fetchCh:
+			c, ok := clusters[req.name]
+			req.resCh <- fetchResult{cluster: c, ok: ok}
+
+		case name := <-s.removeCh:
+			if c, ok := clusters[name]; ok {
+				c.Stop()
+				delete(clusters, name)
+			}
+
+		case resCh := <-s.countCh:
+			resCh <- len(clusters)
+
+		case resCh := <-s.readyCountCh:
+			ready := 0
+			for _, c := range clusters {
+				if c.State() == ClusterStateReady {
+					ready++
+				}
+			}
+			resCh <- ready
+
+		case <-s.stopCh:
+			for _, c := range clusters {
+				c.Stop()
+			}
+			return
+		}
+	}
+}
+
+// Stop shuts down the Server's event loop, tearing down every cached
+// cluster on its way out.
+func (s *Server) Stop() {
+	close(s.stopCh)
+}
+
+// Store inserts c, replacing any existing record for the same cluster
+// name unless the existing record already has the same checksum, in
+// which case the update is discarded. It reports whether c was actually
+// stored, so that callers who key other side effects (e.g. a readiness
+// gauge) off of a cluster record changing don't act on a redundant,
+// discarded update.
+func (s *Server) Store(c *cluster) bool {
+	resCh := make(chan bool)
+	s.storeCh <- storeRequest{cluster: c, resCh: resCh}
+	return <-resCh
+}
+
+func (s *Server) Fetch(name string) (*cluster, bool) {
+	resCh := make(chan fetchResult)
+	s.fetchCh <- fetchRequest{name: name, resCh: resCh}
+	res := <-resCh
+	return res.cluster, res.ok
+}
+
+func (s *Server) Remove(name string) {
+	s.removeCh <- name
+}
+
+func (s *Server) Count() int {
+	resCh := make(chan int)
+	s.countCh <- resCh
+	return <-resCh
+}
+
+// ReadyCount returns the number of cached clusters currently in
+// ClusterStateReady. It's used by readiness checks that want to know
+// whether shipper can reach at least one target cluster, as opposed to
+// Count, which also counts clusters that are Pending, Unreachable or
+// AuthFailed.
+func (s *Server) ReadyCount() int {
+	resCh := make(chan int)
+	s.readyCountCh <- resCh
+	return <-resCh
+}
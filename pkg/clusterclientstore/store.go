@@ -0,0 +1,292 @@
+package clusterclientstore
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubeinformers "k8s.io/client-go/informers"
+	corev1informers "k8s.io/client-go/informers/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+
+	shipperv1alpha1 "github.com/bookingcom/shipper/pkg/apis/shipper/v1alpha1"
+	shipperinformers "github.com/bookingcom/shipper/pkg/client/informers/externalversions/shipper/v1alpha1"
+	clustercache "github.com/bookingcom/shipper/pkg/clusterclientstore/cache"
+	"github.com/bookingcom/shipper/pkg/errors"
+)
+
+const (
+	// minReconnectBackoff and maxReconnectBackoff bound the exponential
+	// backoff applied to the per-cluster reconnect goroutine. We don't
+	// want to hammer an unreachable API server, but we also don't want an
+	// operator to wait minutes after fixing a cluster for shipper to
+	// notice.
+	minReconnectBackoff = 1 * time.Second
+	maxReconnectBackoff = 1 * time.Minute
+
+	healthzTimeout = 5 * time.Second
+)
+
+var targetClusterUp = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Namespace: "shipper",
+		Name:      "target_cluster_up",
+		Help:      "Gauge of whether the target cluster's API server is reachable. 1 if reachable, 0 otherwise.",
+	},
+	[]string{"cluster"},
+)
+
+func init() {
+	prometheus.MustRegister(targetClusterUp)
+}
+
+// Store keeps a cache.Server of per-cluster clients built from the
+// kubeconfig Secrets referenced by shipper Cluster objects. Unlike a plain
+// "build once, cache forever" store, a cluster whose API server cannot be
+// reached is kept around in a degraded state (cache.ClusterStateUnreachable
+// or cache.ClusterStateAuthFailed) rather than dropped, so that a single
+// bad cluster doesn't make Fetch indistinguishable from "never heard of
+// this cluster". A background goroutine per cluster retries with
+// exponential backoff until a lightweight /healthz probe succeeds, at
+// which point the cluster is promoted back to Ready.
+type Store struct {
+	secretInformer  corev1informers.SecretInformer
+	clusterInformer shipperinformers.ClusterInformer
+
+	cache *clustercache.Server
+
+	buildClient func(secret *corev1.Secret) (kubernetes.Interface, *rest.Config, error)
+
+	eventRecorder record.EventRecorder
+}
+
+func NewStore(
+	secretInformer corev1informers.SecretInformer,
+	clusterInformer shipperinformers.ClusterInformer,
+	eventRecorder record.EventRecorder,
+) *Store {
+	s := &Store{
+		secretInformer:  secretInformer,
+		clusterInformer: clusterInformer,
+		cache:           clustercache.NewServer(),
+		buildClient:     buildClientFromSecret,
+		eventRecorder:   eventRecorder,
+	}
+
+	clusterInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    s.enqueueCluster,
+		UpdateFunc: func(_, newObj interface{}) { s.enqueueCluster(newObj) },
+		DeleteFunc: s.deleteCluster,
+	})
+
+	return s
+}
+
+// Run starts the cache.Server's event loop and blocks until stopCh is
+// closed.
+func (s *Store) Run(stopCh <-chan struct{}) {
+	go s.cache.Serve()
+
+	go func() {
+		<-stopCh
+		s.cache.Stop()
+	}()
+
+	<-stopCh
+}
+
+func (s *Store) enqueueCluster(obj interface{}) {
+	clusterObj, ok := obj.(*shipperv1alpha1.Cluster)
+	if !ok {
+		return
+	}
+
+	name := clusterObj.Name
+
+	secretObj, err := s.secretInformer.Lister().Secrets(metav1.NamespaceAll).Get(name)
+	if err != nil {
+		klog.Warningf("cannot fetch secret for cluster %q yet: %s", name, err)
+		return
+	}
+
+	checksum := secretObj.Annotations["shipper.booking.com/cluster-secret-checksum"]
+
+	// A cluster is never declared Ready straight out of enqueueCluster:
+	// buildClient only parses the kubeconfig and builds a client-go
+	// Interface, neither of which contacts the API server. Every cluster
+	// therefore starts out Pending and is only promoted to Ready by the
+	// reconnect loop once a /healthz probe actually succeeds.
+	pending := clustercache.NewPendingCluster(name, checksum)
+	if !s.cache.Store(pending) {
+		// Same checksum as whatever's already cached: Store discarded
+		// this as a redundant update (e.g. a no-op informer resync) and
+		// kept the existing record, which may well be Ready already.
+		// Don't flap its gauge to 0 or spawn a second reconnect loop for
+		// a record that was never actually installed.
+		return
+	}
+	targetClusterUp.WithLabelValues(name).Set(0)
+
+	go s.reconnect(name, checksum, pending)
+}
+
+func (s *Store) deleteCluster(obj interface{}) {
+	clusterObj, ok := obj.(*shipperv1alpha1.Cluster)
+	if !ok {
+		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			return
+		}
+		clusterObj, ok = tombstone.Obj.(*shipperv1alpha1.Cluster)
+		if !ok {
+			return
+		}
+	}
+
+	s.cache.Remove(clusterObj.Name)
+	targetClusterUp.DeleteLabelValues(clusterObj.Name)
+}
+
+// reconnect retries building a client for a cluster that could not be
+// reached, with exponential backoff, until a lightweight /healthz probe
+// succeeds. It gives up (returns) once the cluster has been replaced or
+// removed from the cache, since at that point a fresh call to
+// enqueueCluster owns the reconnection.
+func (s *Store) reconnect(name, checksum string, pending *clustercache.Cluster) {
+	backoff := minReconnectBackoff
+
+	for {
+		if current, ok := s.cache.Fetch(name); !ok || current != pending {
+			return
+		}
+
+		secretObj, err := s.secretInformer.Lister().Secrets(metav1.NamespaceAll).Get(name)
+		if err == nil {
+			client, config, buildErr := s.buildClient(secretObj)
+			if buildErr == nil {
+				if healthzErr := probeHealthz(client); healthzErr == nil {
+					informerFactory := kubeinformers.NewSharedInformerFactory(client, 30*time.Second)
+					factoryStopCh := make(chan struct{})
+
+					pending.SetReady(client, config, informerFactory, func() { close(factoryStopCh) })
+					targetClusterUp.WithLabelValues(name).Set(1)
+					s.recordTransition(name, "ClusterReady", "cluster became reachable")
+
+					informerFactory.Start(factoryStopCh)
+					return
+				} else if errors.IsClusterAuthFailed(healthzErr) {
+					pending.SetAuthFailed(healthzErr)
+					s.recordTransition(name, "ClusterAuthFailed", healthzErr.Error())
+				} else {
+					pending.SetUnreachable(healthzErr)
+				}
+			}
+		}
+
+		time.Sleep(backoff)
+
+		backoff *= 2
+		if backoff > maxReconnectBackoff {
+			backoff = maxReconnectBackoff
+		}
+	}
+}
+
+func (s *Store) recordTransition(clusterName, reason, message string) {
+	if s.eventRecorder == nil {
+		return
+	}
+
+	clusterObj, err := s.clusterInformer.Lister().Get(clusterName)
+	if err != nil {
+		return
+	}
+
+	s.eventRecorder.Event(clusterObj, corev1.EventTypeNormal, reason, message)
+}
+
+// Fetch returns a client for the named cluster if it is Ready. Callers
+// that need to tell "unreachable" and "auth failed" apart from "not ready
+// yet" should use errors.IsClusterUnreachable/errors.IsClusterAuthFailed
+// on the returned error.
+func (s *Store) Fetch(clusterName string) (kubernetes.Interface, error) {
+	c, ok := s.cache.Fetch(clusterName)
+	if !ok {
+		return nil, errors.NewClusterNotReadyError(clusterName)
+	}
+
+	return c.GetClient()
+}
+
+func (s *Store) FetchConfig(clusterName string) (*rest.Config, error) {
+	c, ok := s.cache.Fetch(clusterName)
+	if !ok {
+		return nil, errors.NewClusterNotReadyError(clusterName)
+	}
+
+	return c.GetConfig()
+}
+
+func (s *Store) FetchInformerFactory(clusterName string) (kubeinformers.SharedInformerFactory, error) {
+	c, ok := s.cache.Fetch(clusterName)
+	if !ok {
+		return nil, errors.NewClusterNotReadyError(clusterName)
+	}
+
+	return c.GetInformerFactory()
+}
+
+// ReadyClusterCount returns the number of target clusters the store
+// currently considers Ready. It's meant for readiness checks: a shipper
+// replica that hasn't reached any target cluster yet can't reconcile
+// anything, even if its own caches are synced.
+func (s *Store) ReadyClusterCount() int {
+	return s.cache.ReadyCount()
+}
+
+func buildClientFromSecret(secret *corev1.Secret) (kubernetes.Interface, *rest.Config, error) {
+	config, err := clientcmd.RESTConfigFromKubeConfig(secret.Data["kubeconfig"])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	client, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return client, config, nil
+}
+
+// probeHealthz performs a single lightweight GET against the target
+// cluster's /healthz endpoint, returning an errors.ClusterAuthFailedError
+// for 401/403 responses so the reconnect loop can distinguish "still
+// unreachable" from "credentials are bad and retrying won't help".
+//
+// This goes through client.Discovery().RESTClient() rather than building a
+// rest.RESTClient directly off the *rest.Config: a bare
+// rest.UnversionedRESTClientFor(config) requires a NegotiatedSerializer
+// (and GroupVersion) that buildClientFromSecret's config never sets, and
+// fails with "NegotiatedSerializer is required..." on every call. The
+// discovery client already carries a working codec for unversioned
+// endpoints like /healthz.
+func probeHealthz(client kubernetes.Interface) error {
+	result := client.Discovery().RESTClient().Get().AbsPath("/healthz").Timeout(healthzTimeout).Do()
+	var statusCode int
+	result.StatusCode(&statusCode)
+
+	if statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden {
+		return errors.NewClusterAuthFailedError("", fmt.Errorf("healthz returned %d", statusCode))
+	}
+
+	return result.Error()
+}
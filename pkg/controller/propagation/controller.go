@@ -0,0 +1,500 @@
+package propagation
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+
+	shipperv1alpha1 "github.com/bookingcom/shipper/pkg/apis/shipper/v1alpha1"
+	shipperclientset "github.com/bookingcom/shipper/pkg/client/clientset/versioned"
+	shipperinformers "github.com/bookingcom/shipper/pkg/client/informers/externalversions"
+	shipperlisters "github.com/bookingcom/shipper/pkg/client/listers/shipper/v1alpha1"
+)
+
+// AgentName is the name this controller uses when it creates events and
+// registers itself with the informer factory's shared caches.
+const AgentName = "propagation-controller"
+
+// Controller resolves the effective PropagationPolicy/ClusterPropagationPolicy
+// and OverridePolicy/ClusterOverridePolicy for each Release, and writes the
+// resulting placement into the InstallationTarget and CapacityTarget objects
+// the installation and capacity controllers already watch. This decouples
+// rollout topology from the Application object: operators can retarget
+// clusters or add overrides without touching every Application.
+type Controller struct {
+	shipperClient shipperclientset.Interface
+
+	releaseLister                 shipperlisters.ReleaseLister
+	releasesSynced                cache.InformerSynced
+	propagationPolicyLister       shipperlisters.PropagationPolicyLister
+	propagationPoliciesSynced     cache.InformerSynced
+	clusterPropPolicyLister       shipperlisters.ClusterPropagationPolicyLister
+	clusterPropPoliciesSynced     cache.InformerSynced
+	overridePolicyLister          shipperlisters.OverridePolicyLister
+	overridePoliciesSynced        cache.InformerSynced
+	clusterOverridePolicyLister   shipperlisters.ClusterOverridePolicyLister
+	clusterOverridePoliciesSynced cache.InformerSynced
+	clusterLister                 shipperlisters.ClusterLister
+	clustersSynced                cache.InformerSynced
+	installationTargetLister      shipperlisters.InstallationTargetLister
+	installationTargetsSynced     cache.InformerSynced
+	capacityTargetLister          shipperlisters.CapacityTargetLister
+	capacityTargetsSynced         cache.InformerSynced
+
+	workqueue workqueue.RateLimitingInterface
+	recorder  record.EventRecorder
+	logger    logr.Logger
+}
+
+func NewController(
+	shipperClient shipperclientset.Interface,
+	shipperInformerFactory shipperinformers.SharedInformerFactory,
+	recorder record.EventRecorder,
+	logger logr.Logger,
+) *Controller {
+	releaseInformer := shipperInformerFactory.Shipper().V1().Releases()
+	propagationPolicyInformer := shipperInformerFactory.Shipper().V1().PropagationPolicies()
+	clusterPropPolicyInformer := shipperInformerFactory.Shipper().V1().ClusterPropagationPolicies()
+	overridePolicyInformer := shipperInformerFactory.Shipper().V1().OverridePolicies()
+	clusterOverridePolicyInformer := shipperInformerFactory.Shipper().V1().ClusterOverridePolicies()
+	clusterInformer := shipperInformerFactory.Shipper().V1().Clusters()
+	installationTargetInformer := shipperInformerFactory.Shipper().V1().InstallationTargets()
+	capacityTargetInformer := shipperInformerFactory.Shipper().V1().CapacityTargets()
+
+	c := &Controller{
+		shipperClient: shipperClient,
+
+		releaseLister:                  releaseInformer.Lister(),
+		releasesSynced:                 releaseInformer.Informer().HasSynced,
+		propagationPolicyLister:        propagationPolicyInformer.Lister(),
+		propagationPoliciesSynced:      propagationPolicyInformer.Informer().HasSynced,
+		clusterPropPolicyLister:        clusterPropPolicyInformer.Lister(),
+		clusterPropPoliciesSynced:      clusterPropPolicyInformer.Informer().HasSynced,
+		overridePolicyLister:           overridePolicyInformer.Lister(),
+		overridePoliciesSynced:         overridePolicyInformer.Informer().HasSynced,
+		clusterOverridePolicyLister:    clusterOverridePolicyInformer.Lister(),
+		clusterOverridePoliciesSynced:  clusterOverridePolicyInformer.Informer().HasSynced,
+		clusterLister:                  clusterInformer.Lister(),
+		clustersSynced:                 clusterInformer.Informer().HasSynced,
+		installationTargetLister:       installationTargetInformer.Lister(),
+		installationTargetsSynced:      installationTargetInformer.Informer().HasSynced,
+		capacityTargetLister:           capacityTargetInformer.Lister(),
+		capacityTargetsSynced:          capacityTargetInformer.Informer().HasSynced,
+
+		workqueue: workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), AgentName),
+		recorder:  recorder,
+		logger:    logger.WithName(AgentName),
+	}
+
+	releaseInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.enqueueRelease,
+		UpdateFunc: func(_, newObj interface{}) { c.enqueueRelease(newObj) },
+	})
+
+	requeueAllReleases := func(interface{}) { c.enqueueAllReleases() }
+	propagationPolicyInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    requeueAllReleases,
+		UpdateFunc: func(_, _ interface{}) { requeueAllReleases(nil) },
+		DeleteFunc: requeueAllReleases,
+	})
+	clusterPropPolicyInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    requeueAllReleases,
+		UpdateFunc: func(_, _ interface{}) { requeueAllReleases(nil) },
+		DeleteFunc: requeueAllReleases,
+	})
+	overridePolicyInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    requeueAllReleases,
+		UpdateFunc: func(_, _ interface{}) { requeueAllReleases(nil) },
+		DeleteFunc: requeueAllReleases,
+	})
+	clusterOverridePolicyInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    requeueAllReleases,
+		UpdateFunc: func(_, _ interface{}) { requeueAllReleases(nil) },
+		DeleteFunc: requeueAllReleases,
+	})
+
+	return c
+}
+
+func (c *Controller) Run(workers int, stopCh <-chan struct{}) {
+	defer utilruntime.HandleCrash()
+	defer c.workqueue.ShutDown()
+
+	c.logger.V(2).Info("starting")
+	defer c.logger.V(2).Info("shutting down")
+
+	if !cache.WaitForCacheSync(
+		stopCh,
+		c.releasesSynced,
+		c.propagationPoliciesSynced,
+		c.clusterPropPoliciesSynced,
+		c.overridePoliciesSynced,
+		c.clusterOverridePoliciesSynced,
+		c.clustersSynced,
+		c.installationTargetsSynced,
+		c.capacityTargetsSynced,
+	) {
+		utilruntime.HandleError(fmt.Errorf("failed to wait for propagation controller caches to sync"))
+		return
+	}
+
+	for i := 0; i < workers; i++ {
+		go wait.Until(c.runWorker, time.Second, stopCh)
+	}
+
+	<-stopCh
+}
+
+func (c *Controller) runWorker() {
+	for c.processNextWorkItem() {
+	}
+}
+
+func (c *Controller) processNextWorkItem() bool {
+	obj, shutdown := c.workqueue.Get()
+	if shutdown {
+		return false
+	}
+	defer c.workqueue.Done(obj)
+
+	key, ok := obj.(string)
+	if !ok {
+		c.workqueue.Forget(obj)
+		utilruntime.HandleError(fmt.Errorf("expected string in workqueue but got %#v", obj))
+		return true
+	}
+
+	if err := c.syncHandler(key); err != nil {
+		c.workqueue.AddRateLimited(key)
+		utilruntime.HandleError(fmt.Errorf("error syncing release %q: %s", key, err))
+		return true
+	}
+
+	c.workqueue.Forget(obj)
+	return true
+}
+
+// syncHandler resolves the effective PropagationPolicy/ClusterPropagationPolicy
+// placement and OverridePolicy/ClusterOverridePolicy overrides for the named
+// Release, and writes the result into the matching InstallationTarget and
+// CapacityTarget, both of which shipper convention names identically to
+// their Release.
+func (c *Controller) syncHandler(key string) error {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("invalid resource key %q", key))
+		return nil
+	}
+
+	release, err := c.releaseLister.Releases(namespace).Get(name)
+	if err != nil {
+		// The Release was deleted; nothing left for us to reconcile.
+		return nil
+	}
+
+	placement, err := c.resolvePlacement(release)
+	if err != nil {
+		return err
+	}
+	if placement == nil {
+		// No PropagationPolicy selects this Release: leave whatever
+		// placement the Application/InstallationTarget already have.
+		return nil
+	}
+
+	clusterNames, err := c.resolveClusterNames(placement)
+	if err != nil {
+		return err
+	}
+	if len(clusterNames) == 0 {
+		// The policy's Clusters/ClusterSelector/Regions resolved to no
+		// clusters at all; refuse to wipe out whatever placement the
+		// InstallationTarget/CapacityTarget already carry.
+		c.recorder.Eventf(release, "Warning", "PlacementResolutionFailed", "PropagationPolicy for release %q resolved to zero clusters", name)
+		return nil
+	}
+
+	overrides, err := c.resolveOverrides(release)
+	if err != nil {
+		return err
+	}
+
+	if err := c.applyToInstallationTarget(namespace, name, clusterNames, overrides); err != nil {
+		return err
+	}
+	if err := c.applyToCapacityTarget(namespace, name, clusterNames, placement.Weights); err != nil {
+		return err
+	}
+
+	c.recorder.Eventf(release, "Normal", "PlacementResolved", "resolved placement for clusters %v", clusterNames)
+
+	return nil
+}
+
+// resolvePlacement finds the PropagationPolicy or ClusterPropagationPolicy
+// (in that precedence order) whose selector matches release's labels, and
+// returns its ClusterPlacement. It returns (nil, nil) if no policy
+// matches.
+func (c *Controller) resolvePlacement(release *shipperv1alpha1.Release) (*shipperv1alpha1.ClusterPlacement, error) {
+	policies, err := c.propagationPolicyLister.PropagationPolicies(release.Namespace).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, policy := range policies {
+		selector, err := metav1.LabelSelectorAsSelector(&policy.Spec.Selector)
+		if err != nil {
+			utilruntime.HandleError(fmt.Errorf("invalid selector on PropagationPolicy %s/%s: %s", policy.Namespace, policy.Name, err))
+			continue
+		}
+		if selector.Matches(labels.Set(release.Labels)) {
+			return &policy.Spec.Placement, nil
+		}
+	}
+
+	clusterPolicies, err := c.clusterPropPolicyLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, policy := range clusterPolicies {
+		selector, err := metav1.LabelSelectorAsSelector(&policy.Spec.Selector)
+		if err != nil {
+			utilruntime.HandleError(fmt.Errorf("invalid selector on ClusterPropagationPolicy %s: %s", policy.Name, err))
+			continue
+		}
+		if selector.Matches(labels.Set(release.Labels)) {
+			return &policy.Spec.Placement, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// resolveClusterNames expands a ClusterPlacement into an explicit,
+// deduplicated set of target cluster names: Clusters is taken verbatim,
+// ClusterSelector is matched against every known Cluster's labels, and
+// Regions is matched against every known Cluster's Spec.Region. The three
+// selection mechanisms are additive, not exclusive: a cluster picked up by
+// any one of them is included. Weights are not resolved here; they only
+// affect how capacity is split across the returned names, see
+// applyToCapacityTarget.
+func (c *Controller) resolveClusterNames(placement *shipperv1alpha1.ClusterPlacement) ([]string, error) {
+	names := make(map[string]struct{}, len(placement.Clusters))
+	for _, name := range placement.Clusters {
+		names[name] = struct{}{}
+	}
+
+	if placement.ClusterSelector == nil && len(placement.Regions) == 0 {
+		return setToSortedSlice(names), nil
+	}
+
+	clusters, err := c.clusterLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	var selector labels.Selector
+	if placement.ClusterSelector != nil {
+		selector, err = metav1.LabelSelectorAsSelector(placement.ClusterSelector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid clusterSelector: %s", err)
+		}
+	}
+
+	regions := make(map[string]struct{}, len(placement.Regions))
+	for _, region := range placement.Regions {
+		regions[region] = struct{}{}
+	}
+
+	for _, cluster := range clusters {
+		if selector != nil && selector.Matches(labels.Set(cluster.Labels)) {
+			names[cluster.Name] = struct{}{}
+		}
+		if _, ok := regions[cluster.Spec.Region]; ok {
+			names[cluster.Name] = struct{}{}
+		}
+	}
+
+	return setToSortedSlice(names), nil
+}
+
+func setToSortedSlice(set map[string]struct{}) []string {
+	names := make([]string, 0, len(set))
+	for name := range set {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// resolveOverrides finds the OverridePolicy or ClusterOverridePolicy (in
+// that precedence order) whose selector matches release's labels, and
+// returns its ClusterOverrides. It returns (nil, nil) if no policy
+// matches, meaning every target cluster gets the chart's values
+// unmodified.
+func (c *Controller) resolveOverrides(release *shipperv1alpha1.Release) ([]shipperv1alpha1.ClusterOverride, error) {
+	policies, err := c.overridePolicyLister.OverridePolicies(release.Namespace).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, policy := range policies {
+		selector, err := metav1.LabelSelectorAsSelector(&policy.Spec.Selector)
+		if err != nil {
+			utilruntime.HandleError(fmt.Errorf("invalid selector on OverridePolicy %s/%s: %s", policy.Namespace, policy.Name, err))
+			continue
+		}
+		if selector.Matches(labels.Set(release.Labels)) {
+			return policy.Spec.ClusterOverrides, nil
+		}
+	}
+
+	clusterPolicies, err := c.clusterOverridePolicyLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, policy := range clusterPolicies {
+		selector, err := metav1.LabelSelectorAsSelector(&policy.Spec.Selector)
+		if err != nil {
+			utilruntime.HandleError(fmt.Errorf("invalid selector on ClusterOverridePolicy %s: %s", policy.Name, err))
+			continue
+		}
+		if selector.Matches(labels.Set(release.Labels)) {
+			return policy.Spec.ClusterOverrides, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// resolveWeights turns a ClusterPlacement's Weights into an explicit
+// percent-of-capacity per cluster name, for use on CapacityTarget. Clusters
+// named in clusterNames but absent from weights divide whatever share of
+// the 100% is left over equally between them; if weights is empty, every
+// cluster in clusterNames gets an equal share.
+func resolveWeights(clusterNames []string, weights map[string]int32) map[string]int32 {
+	percents := make(map[string]int32, len(clusterNames))
+
+	var weightedTotal int32
+	var unweighted []string
+	for _, name := range clusterNames {
+		if w, ok := weights[name]; ok {
+			percents[name] = w
+			weightedTotal += w
+		} else {
+			unweighted = append(unweighted, name)
+		}
+	}
+
+	if len(unweighted) == 0 {
+		return percents
+	}
+
+	remaining := int32(100) - weightedTotal
+	if remaining < 0 {
+		remaining = 0
+	}
+	share := remaining / int32(len(unweighted))
+	extra := remaining % int32(len(unweighted))
+	for i, name := range unweighted {
+		w := share
+		if int32(i) < extra {
+			w++
+		}
+		percents[name] = w
+	}
+
+	return percents
+}
+
+// applyToInstallationTarget writes the resolved placement and overrides
+// onto the InstallationTarget's spec; it's the installation controller's
+// job to read Spec.ClusterOverrides back out and apply each patch to the
+// chart values it installs on the named clusters.
+func (c *Controller) applyToInstallationTarget(namespace, name string, clusterNames []string, overrides []shipperv1alpha1.ClusterOverride) error {
+	it, err := c.installationTargetLister.InstallationTargets(namespace).Get(name)
+	if err != nil {
+		return err
+	}
+
+	if reflect.DeepEqual(it.Spec.Clusters, clusterNames) && reflect.DeepEqual(it.Spec.ClusterOverrides, overrides) {
+		return nil
+	}
+
+	itCopy := it.DeepCopy()
+	itCopy.Spec.Clusters = clusterNames
+	itCopy.Spec.ClusterOverrides = overrides
+
+	_, err = c.shipperClient.ShipperV1alpha1().InstallationTargets(namespace).Update(itCopy)
+	return err
+}
+
+// applyToCapacityTarget writes clusterNames into the CapacityTarget's
+// per-cluster entries, preserving the Percent/Replicas already recorded
+// for clusters that remain in the set rather than overwriting the whole
+// []ClusterCapacityTarget slice (which would throw away everything the
+// capacity controller has computed so far). Clusters newly added by this
+// sync get their Percent from resolveWeights and start at zero replicas
+// until the capacity controller scales them up; clusters dropped from the
+// placement are removed entirely.
+func (c *Controller) applyToCapacityTarget(namespace, name string, clusterNames []string, weights map[string]int32) error {
+	ct, err := c.capacityTargetLister.CapacityTargets(namespace).Get(name)
+	if err != nil {
+		return err
+	}
+
+	percents := resolveWeights(clusterNames, weights)
+
+	existing := make(map[string]shipperv1alpha1.ClusterCapacityTarget, len(ct.Spec.Clusters))
+	for _, cct := range ct.Spec.Clusters {
+		existing[cct.Name] = cct
+	}
+
+	desired := make([]shipperv1alpha1.ClusterCapacityTarget, 0, len(clusterNames))
+	for _, clusterName := range clusterNames {
+		cct, ok := existing[clusterName]
+		if !ok {
+			cct = shipperv1alpha1.ClusterCapacityTarget{Name: clusterName}
+			cct.Percent = percents[clusterName]
+		}
+		desired = append(desired, cct)
+	}
+
+	if reflect.DeepEqual(ct.Spec.Clusters, desired) {
+		return nil
+	}
+
+	ctCopy := ct.DeepCopy()
+	ctCopy.Spec.Clusters = desired
+
+	_, err = c.shipperClient.ShipperV1alpha1().CapacityTargets(namespace).Update(ctCopy)
+	return err
+}
+
+func (c *Controller) enqueueRelease(obj interface{}) {
+	key, err := cache.MetaNamespaceKeyFunc(obj)
+	if err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+	c.workqueue.Add(key)
+}
+
+func (c *Controller) enqueueAllReleases() {
+	releases, err := c.releaseLister.List(labels.Everything())
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("error listing releases to requeue: %s", err))
+		return
+	}
+	for _, release := range releases {
+		c.enqueueRelease(release)
+	}
+}
@@ -0,0 +1,78 @@
+package errors
+
+import "fmt"
+
+// ClusterNotReadyError is returned when a cluster has been registered in the
+// store but hasn't finished building a client/config/informer factory yet,
+// has been replaced by a newer record for the same cluster name, or isn't
+// known to the store at all.
+type ClusterNotReadyError struct {
+	ClusterName string
+}
+
+func (e ClusterNotReadyError) Error() string {
+	return fmt.Sprintf("cluster %q is not ready yet", e.ClusterName)
+}
+
+func NewClusterNotReadyError(clusterName string) ClusterNotReadyError {
+	return ClusterNotReadyError{ClusterName: clusterName}
+}
+
+func IsClusterNotReady(err error) bool {
+	_, ok := err.(ClusterNotReadyError)
+	return ok
+}
+
+// ClusterUnreachableError is returned when a cluster's kubeconfig secret
+// looks valid, but its API server could not be reached (e.g. a dial
+// timeout or a failed /healthz probe). It is distinct from
+// ClusterNotReadyError so that callers can tell "API server failing its
+// probes, still being retried in the background" apart from "client not
+// built yet".
+type ClusterUnreachableError struct {
+	ClusterName string
+	Err         error
+}
+
+func (e ClusterUnreachableError) Error() string {
+	return fmt.Sprintf("cluster %q is unreachable: %s", e.ClusterName, e.Err)
+}
+
+func (e ClusterUnreachableError) Unwrap() error {
+	return e.Err
+}
+
+func NewClusterUnreachableError(clusterName string, err error) ClusterUnreachableError {
+	return ClusterUnreachableError{ClusterName: clusterName, Err: err}
+}
+
+func IsClusterUnreachable(err error) bool {
+	_, ok := err.(ClusterUnreachableError)
+	return ok
+}
+
+// ClusterAuthFailedError is returned when a cluster's API server rejected
+// the credentials found in its kubeconfig secret. Unlike
+// ClusterUnreachableError, retrying without operator intervention (e.g.
+// rotating the secret) will not help.
+type ClusterAuthFailedError struct {
+	ClusterName string
+	Err         error
+}
+
+func (e ClusterAuthFailedError) Error() string {
+	return fmt.Sprintf("cluster %q failed authentication: %s", e.ClusterName, e.Err)
+}
+
+func (e ClusterAuthFailedError) Unwrap() error {
+	return e.Err
+}
+
+func NewClusterAuthFailedError(clusterName string, err error) ClusterAuthFailedError {
+	return ClusterAuthFailedError{ClusterName: clusterName, Err: err}
+}
+
+func IsClusterAuthFailed(err error) bool {
+	_, ok := err.(ClusterAuthFailedError)
+	return ok
+}
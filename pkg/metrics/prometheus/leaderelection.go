@@ -0,0 +1,13 @@
+package prometheus
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// LeaderElectionMasterStatus reports whether this process currently holds
+// the leader election lock. It is 1 on the leader and 0 on every standby
+// replica, so a simple `max` query across instances tells you whether a
+// leader is currently held at all.
+var LeaderElectionMasterStatus = prometheus.NewGauge(prometheus.GaugeOpts{
+	Namespace: "shipper",
+	Name:      "leader_election_master_status",
+	Help:      "Gauge of whether the reporting instance is the elected leader. 1 indicates leader, 0 indicates standby.",
+})